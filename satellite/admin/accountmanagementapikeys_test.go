@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/admin"
 	"storj.io/storj/satellite/oidc"
 )
 
@@ -66,7 +68,7 @@ func TestAccountManagementAPIKeys(t *testing.T) {
 			err = json.Unmarshal(responseBody, &output)
 			require.NoError(t, err)
 
-			userID, err := keyService.GetUserFromKey(ctx, output.APIKey)
+			userID, _, err := keyService.GetUserFromKey(ctx, output.APIKey)
 			require.NoError(t, err)
 			require.Equal(t, user.ID, userID)
 
@@ -103,7 +105,7 @@ func TestAccountManagementAPIKeys(t *testing.T) {
 			err = json.Unmarshal(responseBody, &output)
 			require.NoError(t, err)
 
-			userID, err := keyService.GetUserFromKey(ctx, output.APIKey)
+			userID, _, err := keyService.GetUserFromKey(ctx, output.APIKey)
 			require.NoError(t, err)
 			require.Equal(t, user.ID, userID)
 
@@ -114,6 +116,34 @@ func TestAccountManagementAPIKeys(t *testing.T) {
 			require.True(t, output.ExpiresAt.Before(now.Add(durationTime+time.Hour)))
 		})
 
+		t.Run("create exceeding max expiration", func(t *testing.T) {
+			maxExpiration := satellite.Config.AccountManagementAPIKeys.MaxExpiration
+			durationString := (maxExpiration + time.Hour).String()
+			body := strings.NewReader(fmt.Sprintf(`{"expiration":"%s"}`, durationString))
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://"+address.String()+"/api/accountmanagementapikeys/%s", user.Email), body)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", satellite.Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+		})
+
+		t.Run("create with never expires disallowed by default", func(t *testing.T) {
+			require.False(t, satellite.Config.AccountManagementAPIKeys.AllowNeverExpire)
+
+			body := strings.NewReader(`{"neverExpire":true}`)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://"+address.String()+"/api/accountmanagementapikeys/%s", user.Email), body)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", satellite.Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+		})
+
 		t.Run("revoke key", func(t *testing.T) {
 			apiKey := testrand.UUID().String()
 			hash, err := keyService.HashKey(ctx, apiKey)
@@ -136,8 +166,93 @@ func TestAccountManagementAPIKeys(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			_, err = keyService.GetUserFromKey(ctx, apiKey)
+			_, _, err = keyService.GetUserFromKey(ctx, apiKey)
 			require.Error(t, err)
 		})
+
+		t.Run("bulk revoke for user", func(t *testing.T) {
+			apiKey := testrand.UUID().String()
+			hash, err := keyService.HashKey(ctx, apiKey)
+			require.NoError(t, err)
+
+			_, err = keyService.InsertIntoDB(ctx, oidc.OAuthToken{
+				UserID: user.ID,
+				Kind:   oidc.KindAccountManagementTokenV0,
+				Token:  hash,
+			}, time.Now(), time.Hour)
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("http://"+address.String()+"/api/accountmanagementapikeys/user/%s", user.Email), nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", satellite.Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+
+			_, _, err = keyService.GetUserFromKey(ctx, apiKey)
+			require.Error(t, err)
+		})
+
+		t.Run("list keys", func(t *testing.T) {
+			body := strings.NewReader(`{"expiration":"","name":"ci-key","scopes":["projects:read","usage:read"]}`)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://"+address.String()+"/api/accountmanagementapikeys/%s", user.Email), body)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", satellite.Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://"+address.String()+"/api/accountmanagementapikeys/%s", user.Email), nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", satellite.Config.Console.AuthToken)
+
+			response, err = http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+
+			responseBody, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+
+			var keys []struct {
+				Name   string   `json:"name"`
+				Scopes []string `json:"scopes"`
+			}
+			require.NoError(t, json.Unmarshal(responseBody, &keys))
+			require.NotEmpty(t, keys)
+
+			var found bool
+			for _, key := range keys {
+				if key.Name == "ci-key" {
+					found = true
+					require.ElementsMatch(t, []string{"projects:read", "usage:read"}, key.Scopes)
+				}
+			}
+			require.True(t, found)
+		})
 	})
-}
\ No newline at end of file
+}
+
+func TestAccountManagementAPIKeysConfigDefaults(t *testing.T) {
+	defaultExpiration := configFieldDefault(t, "DefaultExpiration")
+	maxExpiration := configFieldDefault(t, "MaxExpiration")
+
+	// MaxExpiration must be at least DefaultExpiration, or every
+	// default-expiration key creation would be rejected by the cap.
+	require.GreaterOrEqual(t, maxExpiration, defaultExpiration)
+}
+
+// configFieldDefault parses the "default" struct tag of the named
+// admin.AccountManagementAPIKeysConfig field as a time.Duration.
+func configFieldDefault(t *testing.T, fieldName string) time.Duration {
+	field, ok := reflect.TypeOf(admin.AccountManagementAPIKeysConfig{}).FieldByName(fieldName)
+	require.True(t, ok, "no such field %q", fieldName)
+
+	duration, err := time.ParseDuration(field.Tag.Get("default"))
+	require.NoError(t, err)
+	return duration
+}
@@ -0,0 +1,15 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+// Config configures the satellite admin peer's HTTP server. The account
+// management API key policy lives in its own top-level
+// satellite.Config.AccountManagementAPIKeys rather than here, since it is
+// shared with the console session exchange flow. Likewise, the operator
+// bearer token used to authenticate requests is satellite.Config.Console's
+// AuthToken, passed into NewService and checked via Service.isAdminAuthToken
+// rather than duplicated here.
+type Config struct {
+	Address string `help:"admin peer http listen address" releaseDefault:"" devDefault:"127.0.0.1:8080"`
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"context"
+	"net"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+// Peer is the satellite admin process.
+type Peer struct {
+	Log *zap.Logger
+
+	Admin *Server
+}
+
+// NewPeer constructs a Peer, binding config.Address and wiring the account
+// management API key routes onto its HTTP server.
+func NewPeer(log *zap.Logger, config Config, accountManagementAPIKeys *Service) (*Peer, error) {
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	return &Peer{
+		Log:   log,
+		Admin: NewServer(log, listener, config, accountManagementAPIKeys),
+	}, nil
+}
+
+// Run runs the peer until ctx is canceled.
+func (peer *Peer) Run(ctx context.Context) error {
+	return peer.Admin.Run(ctx)
+}
+
+// Close releases the peer's resources.
+func (peer *Peer) Close() error {
+	return peer.Admin.Close()
+}
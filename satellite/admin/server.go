@@ -0,0 +1,91 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// Server is the satellite admin peer's HTTP server.
+type Server struct {
+	log    *zap.Logger
+	config Config
+
+	accountManagementAPIKeys *Service
+
+	Listener net.Listener
+	server   http.Server
+}
+
+// NewServer constructs a Server, registering the account management API key
+// routes on its own router.
+func NewServer(log *zap.Logger, listener net.Listener, config Config, accountManagementAPIKeys *Service) *Server {
+	server := &Server{
+		log:                      log,
+		config:                   config,
+		accountManagementAPIKeys: accountManagementAPIKeys,
+		Listener:                 listener,
+	}
+
+	// httprouter forbids a static sibling and a wildcard sibling at the same
+	// tree position for the same method. /:email (POST, GET) and /:key/revoke
+	// (PUT) must keep their existing paths, so exchange and audit - which
+	// would otherwise sit as static siblings of /:email under the same
+	// methods - get their own top-level path instead of nesting under
+	// /api/accountmanagementapikeys/.
+	router := httprouter.New()
+	router.HandlerFunc(http.MethodPost, "/api/accountmanagementapikeys-exchange", accountManagementAPIKeys.Exchange)
+	router.HandlerFunc(http.MethodGet, "/api/accountmanagementapikeys-audit", server.withAuth(accountManagementAPIKeys.AuditLog))
+	router.HandlerFunc(http.MethodDelete, "/api/accountmanagementapikeys/user/:email", server.withAuth(accountManagementAPIKeys.RevokeAllForUserHandler))
+	router.HandlerFunc(http.MethodPost, "/api/accountmanagementapikeys/:email", server.withAuth(accountManagementAPIKeys.CreateKey))
+	router.HandlerFunc(http.MethodGet, "/api/accountmanagementapikeys/:email", accountManagementAPIKeys.ListKeys)
+	router.HandlerFunc(http.MethodPut, "/api/accountmanagementapikeys/:key/revoke", server.withAuth(accountManagementAPIKeys.RevokeKey))
+
+	server.server.Handler = router
+
+	return server
+}
+
+// withAuth requires the operator's shared admin AuthToken on the request
+// before invoking next, accepting it in either bare or "Bearer <token>" form
+// (see Service.isAdminAuthToken) so admin auth is consistent across every
+// endpoint, including ListKeys (which also accepts a scoped account
+// management API key, see Service.ListKeys, and so handles its own
+// authentication rather than being wrapped with this). The key-exchange
+// endpoint likewise handles its own authentication.
+func (server *Server) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !server.accountManagementAPIKeys.isAdminAuthToken(r) {
+			httpJSONError(w, "unauthorized", "", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Run starts the server and blocks until ctx is canceled or the server
+// fails.
+func (server *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.server.Serve(server.Listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return server.Close()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Close shuts down the server.
+func (server *Server) Close() error {
+	return server.server.Close()
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellite
+
+import (
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/satellite/admin"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+// API is the satellite's api process. It currently only carries the
+// subsystems the account management API key feature depends on; the rest of
+// the satellite's subsystems live alongside it.
+type API struct {
+	AccountManagementAPIKeys struct {
+		Service *admin.Service
+	}
+}
+
+// NewAPI constructs an API, wiring the account management API key service to
+// its satellitedb-backed storage. authToken is the operator's shared admin
+// bearer token (config.Console.AuthToken).
+func NewAPI(log *zap.Logger, db tagsql.DB, config Config, users admin.Users, consoleAuth admin.ConsoleAuth, authToken string) *API {
+	api := &API{}
+
+	api.AccountManagementAPIKeys.Service = admin.NewService(
+		log.Named("accountmanagementapikeys"),
+		config.AccountManagementAPIKeys,
+		satellitedb.OAuthTokens(db),
+		users,
+		consoleAuth,
+		satellitedb.OAuthAuditLog(db),
+		authToken,
+	)
+
+	return api
+}
@@ -0,0 +1,27 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellite
+
+import (
+	"storj.io/storj/satellite/admin"
+)
+
+// ConsoleConfig holds the subset of console configuration the account
+// management API key feature depends on.
+type ConsoleConfig struct {
+	// AuthToken is the shared secret the admin API already uses to
+	// authenticate operator requests; account management key creation
+	// reuses it rather than introducing a second secret.
+	AuthToken string `help:"shared secret used to authenticate requests to the admin API" noprefix:"true"`
+}
+
+// Config is the satellite configuration relevant to the account management
+// API key feature. The rest of the satellite's configuration lives
+// alongside its other subsystems.
+type Config struct {
+	Admin   admin.Config
+	Console ConsoleConfig
+
+	AccountManagementAPIKeys admin.AccountManagementAPIKeysConfig
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// AuditAction identifies the kind of operation an AuditEntry records.
+type AuditAction string
+
+// Actions recorded against account management API keys.
+const (
+	AuditActionCreate     AuditAction = "create"
+	AuditActionRevoke     AuditAction = "revoke"
+	AuditActionBulkRevoke AuditAction = "bulk_revoke"
+)
+
+// AuditEntry is a single record of an operation performed against a user's
+// account management API keys, for compliance and incident-response
+// purposes.
+type AuditEntry struct {
+	ID            uuid.UUID
+	Timestamp     time.Time
+	ActorIdentity string
+	TargetUserID  uuid.UUID
+	KeyHashPrefix string
+	Action        AuditAction
+	RemoteIP      string
+}
+
+// AuditCursor paginates through an audit log query.
+type AuditCursor struct {
+	Limit  int
+	Offset int64
+}
+
+// AuditPage is one page of an audit log query.
+type AuditPage struct {
+	Entries []AuditEntry
+	More    bool
+}
+
+// AuditLog exposes persistence for AuditEntry records.
+type AuditLog interface {
+	// Write appends entry to the audit log.
+	Write(ctx context.Context, entry AuditEntry) error
+	// List returns entries for userID recorded between since and until
+	// (both inclusive), most recent first.
+	List(ctx context.Context, userID uuid.UUID, since, until time.Time, cursor AuditCursor) (AuditPage, error)
+}
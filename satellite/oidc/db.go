@@ -0,0 +1,34 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// OAuthTokens exposes persistence for OAuthToken records. The concrete
+// implementation lives alongside the rest of the satellite database layer.
+type OAuthTokens interface {
+	// Insert persists token, returning the expiration time actually stored.
+	// A zero expiresIn means the token never expires, and the returned
+	// expiresAt is the zero time.Time in that case.
+	Insert(ctx context.Context, token OAuthToken, now time.Time, expiresIn time.Duration) (expiresAt time.Time, err error)
+	// Get looks up an unexpired, unrevoked token by its hash, stamping
+	// LastUsedAt with the current time as a side effect.
+	Get(ctx context.Context, kind Kind, hash []byte) (OAuthToken, error)
+	// List returns metadata for every token of the given kind belonging to
+	// userID, most recently created first.
+	List(ctx context.Context, kind Kind, userID uuid.UUID) ([]OAuthToken, error)
+	// Revoke marks the token identified by hash as no longer usable and
+	// returns the token record as it was just before being revoked. Unlike
+	// Get, it is not limited to unexpired tokens, so an already-expired key
+	// can still be revoked.
+	Revoke(ctx context.Context, kind Kind, hash []byte) (OAuthToken, error)
+	// RevokeAllForUser marks every token of the given kind belonging to
+	// userID as no longer usable, returning the number revoked.
+	RevokeAllForUser(ctx context.Context, kind Kind, userID uuid.UUID) (revoked int, err error)
+}
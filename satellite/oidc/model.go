@@ -0,0 +1,82 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc
+
+import (
+	"sort"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// Kind distinguishes the purpose of a persisted OAuthToken record.
+type Kind int
+
+const (
+	// KindUnknown is the zero value of Kind and is never assigned to a
+	// persisted token.
+	KindUnknown Kind = iota
+	// KindAccountManagementTokenV0 identifies a bearer token issued through
+	// the admin account management API (AccountManagementAPIKeys).
+	KindAccountManagementTokenV0
+)
+
+// Scope identifies a single unit of authority that an account management
+// token can be granted, following a "resource:action" convention.
+type Scope string
+
+// Scopes recognized by the account management API.
+const (
+	ScopeProjectsRead Scope = "projects:read"
+	ScopeBucketsWrite Scope = "buckets:write"
+	ScopeBillingRead  Scope = "billing:read"
+	ScopeUsageRead    Scope = "usage:read"
+)
+
+// ScopeSet is a de-duplicated collection of Scopes granted to a token.
+type ScopeSet map[Scope]struct{}
+
+// NewScopeSet builds a ScopeSet from a list of scopes, silently
+// de-duplicating repeated entries.
+func NewScopeSet(scopes ...Scope) ScopeSet {
+	set := make(ScopeSet, len(scopes))
+	for _, scope := range scopes {
+		set[scope] = struct{}{}
+	}
+	return set
+}
+
+// Has reports whether the set grants the given scope.
+func (set ScopeSet) Has(scope Scope) bool {
+	_, ok := set[scope]
+	return ok
+}
+
+// Strings returns the scopes as a sorted slice, primarily for encoding and
+// display purposes.
+func (set ScopeSet) Strings() []string {
+	out := make([]string, 0, len(set))
+	for scope := range set {
+		out = append(out, string(scope))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// OAuthToken is a bearer token issued to a user, e.g. through the admin
+// account management API. Token holds a hash of the bearer secret; the
+// secret itself is never persisted.
+type OAuthToken struct {
+	UserID uuid.UUID
+	Kind   Kind
+	Token  []byte
+	Name   string
+	Scopes ScopeSet
+
+	CreatedAt time.Time
+	// ExpiresAt is the zero time if the token never expires.
+	ExpiresAt time.Time
+	// LastUsedAt is the zero time if the token has never been used.
+	LastUsedAt time.Time
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package migrations
+
+// Steps appends the account management API key schema to the satellite
+// database's migration chain. These are meant to be appended, in order, to
+// the satellite's existing *migrate.Migration.Steps slice alongside the rest
+// of its schema history.
+var Steps = []Step{
+	{
+		Description: "add oauth_tokens table",
+		SQL: `
+			CREATE TABLE oauth_tokens (
+				id uuid NOT NULL,
+				user_id uuid NOT NULL,
+				kind integer NOT NULL,
+				token_hash bytea NOT NULL,
+				name text NOT NULL,
+				scopes text[] NOT NULL,
+				created_at timestamptz NOT NULL,
+				expires_at timestamptz,
+				last_used_at timestamptz,
+				revoked_at timestamptz,
+				PRIMARY KEY ( id )
+			);
+			CREATE UNIQUE INDEX oauth_tokens_kind_token_hash_index ON oauth_tokens ( kind, token_hash );
+			CREATE INDEX oauth_tokens_kind_user_id_index ON oauth_tokens ( kind, user_id );
+		`,
+	},
+	{
+		Description: "add oauth_token_audit_log table",
+		SQL: `
+			CREATE TABLE oauth_token_audit_log (
+				id uuid NOT NULL,
+				timestamp timestamptz NOT NULL,
+				actor_identity text NOT NULL,
+				target_user_id uuid NOT NULL,
+				key_hash_prefix text NOT NULL,
+				action text NOT NULL,
+				remote_ip text NOT NULL,
+				PRIMARY KEY ( id )
+			);
+			CREATE INDEX oauth_token_audit_log_target_user_id_timestamp_index ON oauth_token_audit_log ( target_user_id, timestamp DESC );
+		`,
+	},
+}
+
+// Step is a single migration step, matching the shape of the satellite's
+// existing migrate.Step entries.
+type Step struct {
+	Description string
+	SQL         string
+}
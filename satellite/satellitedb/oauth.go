@@ -0,0 +1,29 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package satellitedb contains the account management API key pieces of the
+// satellite database layer: the oauth_tokens/oauth_token_audit_log tables
+// and their migration, and the oidc.OAuthTokens/oidc.AuditLog
+// implementations backed by them. These slot into the satellite's existing
+// *DB type and migration chain alongside its other tables.
+package satellitedb
+
+import (
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/satellite/oidc"
+)
+
+// Error is the error class for the satellitedb package.
+var Error = errs.Class("satellitedb")
+
+// OAuthTokens returns the satellite's oidc.OAuthTokens implementation.
+func OAuthTokens(db tagsql.DB) oidc.OAuthTokens {
+	return &oauthTokens{db: db}
+}
+
+// OAuthAuditLog returns the satellite's oidc.AuditLog implementation.
+func OAuthAuditLog(db tagsql.DB) oidc.AuditLog {
+	return &oauthAuditLog{db: db}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/satellite/oidc"
+)
+
+// oauthAuditLog implements oidc.AuditLog, backed by the
+// oauth_token_audit_log table added by the "add oauth_token_audit_log table"
+// migration step.
+type oauthAuditLog struct {
+	db tagsql.DB
+}
+
+// Write implements oidc.AuditLog.
+func (log *oauthAuditLog) Write(ctx context.Context, entry oidc.AuditEntry) error {
+	id, err := uuid.New()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, err = log.db.ExecContext(ctx, `
+		INSERT INTO oauth_token_audit_log (id, timestamp, actor_identity, target_user_id, key_hash_prefix, action, remote_ip)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, entry.Timestamp, entry.ActorIdentity, entry.TargetUserID, entry.KeyHashPrefix, entry.Action, entry.RemoteIP)
+	return Error.Wrap(err)
+}
+
+// List implements oidc.AuditLog.
+func (log *oauthAuditLog) List(ctx context.Context, userID uuid.UUID, since, until time.Time, cursor oidc.AuditCursor) (oidc.AuditPage, error) {
+	rows, err := log.db.QueryContext(ctx, `
+		SELECT id, timestamp, actor_identity, key_hash_prefix, action, remote_ip
+		FROM oauth_token_audit_log
+		WHERE target_user_id = ? AND timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`, userID, since, until, cursor.Limit+1, cursor.Offset)
+	if err != nil {
+		return oidc.AuditPage{}, Error.Wrap(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []oidc.AuditEntry
+	for rows.Next() {
+		var entry oidc.AuditEntry
+		entry.TargetUserID = userID
+		if err := rows.Scan(&entry.ID, &entry.Timestamp, &entry.ActorIdentity, &entry.KeyHashPrefix, &entry.Action, &entry.RemoteIP); err != nil {
+			return oidc.AuditPage{}, Error.Wrap(err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return oidc.AuditPage{}, Error.Wrap(err)
+	}
+
+	more := len(entries) > cursor.Limit
+	if more {
+		entries = entries[:cursor.Limit]
+	}
+
+	return oidc.AuditPage{Entries: entries, More: more}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2023 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/satellite/oidc"
+)
+
+// oauthTokens implements oidc.OAuthTokens, backed by the oauth_tokens table
+// added by the "add oauth_tokens table" migration step.
+type oauthTokens struct {
+	db tagsql.DB
+}
+
+// Insert implements oidc.OAuthTokens.
+func (tokens *oauthTokens) Insert(ctx context.Context, token oidc.OAuthToken, now time.Time, expiresIn time.Duration) (expiresAt time.Time, err error) {
+	if expiresIn > 0 {
+		expiresAt = now.Add(expiresIn)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return time.Time{}, Error.Wrap(err)
+	}
+
+	_, err = tokens.db.ExecContext(ctx, `
+		INSERT INTO oauth_tokens (id, user_id, kind, token_hash, name, scopes, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, id, token.UserID, token.Kind, token.Token, token.Name, token.Scopes.Strings(), now, nullableTime(expiresAt))
+	if err != nil {
+		return time.Time{}, Error.Wrap(err)
+	}
+
+	return expiresAt, nil
+}
+
+// Get implements oidc.OAuthTokens. Since a successful Get is a successful
+// use of the token, it also stamps last_used_at.
+func (tokens *oauthTokens) Get(ctx context.Context, kind oidc.Kind, hash []byte) (oidc.OAuthToken, error) {
+	now := time.Now()
+
+	row := tokens.db.QueryRowContext(ctx, `
+		UPDATE oauth_tokens
+		SET last_used_at = ?
+		WHERE kind = ? AND token_hash = ? AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > ?)
+		RETURNING user_id, token_hash, name, scopes, created_at, expires_at, last_used_at
+	`, now, kind, hash, now)
+
+	token, err := scanOAuthToken(row, kind, hash)
+	if err != nil {
+		return oidc.OAuthToken{}, Error.Wrap(err)
+	}
+	return token, nil
+}
+
+// List implements oidc.OAuthTokens.
+func (tokens *oauthTokens) List(ctx context.Context, kind oidc.Kind, userID uuid.UUID) ([]oidc.OAuthToken, error) {
+	rows, err := tokens.db.QueryContext(ctx, `
+		SELECT token_hash, name, scopes, created_at, expires_at, last_used_at
+		FROM oauth_tokens
+		WHERE kind = ? AND user_id = ? AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, kind, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []oidc.OAuthToken
+	for rows.Next() {
+		var (
+			hash       []byte
+			name       string
+			scopes     []string
+			createdAt  time.Time
+			expiresAt  sql.NullTime
+			lastUsedAt sql.NullTime
+		)
+		if err := rows.Scan(&hash, &name, &scopes, &createdAt, &expiresAt, &lastUsedAt); err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		out = append(out, oidc.OAuthToken{
+			UserID:     userID,
+			Kind:       kind,
+			Token:      hash,
+			Name:       name,
+			Scopes:     scopeSetFromStrings(scopes),
+			CreatedAt:  createdAt,
+			ExpiresAt:  expiresAt.Time,
+			LastUsedAt: lastUsedAt.Time,
+		})
+	}
+	return out, Error.Wrap(rows.Err())
+}
+
+// Revoke implements oidc.OAuthTokens. It is not limited to unexpired
+// tokens, so an already-expired key can still be revoked.
+func (tokens *oauthTokens) Revoke(ctx context.Context, kind oidc.Kind, hash []byte) (oidc.OAuthToken, error) {
+	row := tokens.db.QueryRowContext(ctx, `
+		UPDATE oauth_tokens
+		SET revoked_at = ?
+		WHERE kind = ? AND token_hash = ? AND revoked_at IS NULL
+		RETURNING user_id, token_hash, name, scopes, created_at, expires_at, last_used_at
+	`, time.Now(), kind, hash)
+
+	token, err := scanOAuthToken(row, kind, hash)
+	if err != nil {
+		return oidc.OAuthToken{}, Error.Wrap(err)
+	}
+	return token, nil
+}
+
+// RevokeAllForUser implements oidc.OAuthTokens.
+func (tokens *oauthTokens) RevokeAllForUser(ctx context.Context, kind oidc.Kind, userID uuid.UUID) (revoked int, err error) {
+	result, err := tokens.db.ExecContext(ctx, `
+		UPDATE oauth_tokens
+		SET revoked_at = ?
+		WHERE kind = ? AND user_id = ? AND revoked_at IS NULL
+	`, time.Now(), kind, userID)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return int(affected), nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows-style single-row
+// results returned from tagsql.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOAuthToken(row rowScanner, kind oidc.Kind, hash []byte) (oidc.OAuthToken, error) {
+	var (
+		userID     uuid.UUID
+		tokenHash  []byte
+		name       string
+		scopes     []string
+		createdAt  time.Time
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+	)
+	if err := row.Scan(&userID, &tokenHash, &name, &scopes, &createdAt, &expiresAt, &lastUsedAt); err != nil {
+		return oidc.OAuthToken{}, err
+	}
+
+	return oidc.OAuthToken{
+		UserID:     userID,
+		Kind:       kind,
+		Token:      tokenHash,
+		Name:       name,
+		Scopes:     scopeSetFromStrings(scopes),
+		CreatedAt:  createdAt,
+		ExpiresAt:  expiresAt.Time,
+		LastUsedAt: lastUsedAt.Time,
+	}, nil
+}
+
+func scopeSetFromStrings(scopes []string) oidc.ScopeSet {
+	out := make(oidc.ScopeSet, len(scopes))
+	for _, scope := range scopes {
+		out[oidc.Scope(scope)] = struct{}{}
+	}
+	return out
+}
+
+func nullableTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}